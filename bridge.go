@@ -130,60 +130,74 @@ func SimpleAdd(a, b int) int {
 	return result
 }
 
-// Clone clones an MGit repository to the specified local path
+// Clone clones an MGit repository to the specified local path. It is a thin
+// wrapper around CloneWithOptions for backward compatibility.
 func Clone(url, localPath, token string) *CloneResult {
-	NSLog("Clone(%s, %s, %s) called", url, localPath, "***")
-	
+	return CloneWithOptions(&CloneOptions{URL: url, LocalPath: localPath, Token: token})
+}
+
+// CloneWithOptions clones an MGit repository with fine-grained control over
+// depth, single-branch, ref selection, and submodule recursion, so a mobile
+// caller on cellular isn't forced into a full clone
+func CloneWithOptions(opts *CloneOptions) *CloneResult {
+	logInfo("clone started", map[string]interface{}{"url": opts.URL, "dest": opts.LocalPath})
+
 	result := &CloneResult{
 		Success:   false,
 		Message:   "",
 		RepoID:    "",
 		RepoName:  "",
-		LocalPath: localPath,
+		LocalPath: opts.LocalPath,
 	}
-	
+
 	// Validate inputs
-	if url == "" {
+	if opts.URL == "" {
 		result.Message = "Repository URL cannot be empty"
-		NSLog("Clone() failed: %s", result.Message)
+		logError("clone failed", map[string]interface{}{"reason": result.Message})
 		return result
 	}
-	
-	if localPath == "" {
+
+	if opts.LocalPath == "" {
 		result.Message = "Local path cannot be empty"
-		NSLog("Clone() failed: %s", result.Message)
+		logError("clone failed", map[string]interface{}{"reason": result.Message})
 		return result
 	}
-	
-	if token == "" {
+
+	if isSSHURL(opts.URL) {
+		if opts.PrivateKeyPEM == "" {
+			result.Message = "Private key cannot be empty for an SSH repository URL"
+			logError("clone failed", map[string]interface{}{"reason": result.Message})
+			return result
+		}
+	} else if opts.Token == "" {
 		result.Message = "Authentication token cannot be empty"
-		NSLog("Clone() failed: %s", result.Message)
+		logError("clone failed", map[string]interface{}{"reason": result.Message})
 		return result
 	}
-	
+
 	// Check if destination already exists
-	if _, err := os.Stat(localPath); !os.IsNotExist(err) {
-		result.Message = fmt.Sprintf("Destination path already exists: %s", localPath)
-		NSLog("Clone() failed: %s", result.Message)
+	if _, err := os.Stat(opts.LocalPath); !os.IsNotExist(err) {
+		result.Message = fmt.Sprintf("Destination path already exists: %s", opts.LocalPath)
+		logError("clone failed", map[string]interface{}{"reason": result.Message})
 		return result
 	}
-	
+
 	// Call the actual MGit clone function
-	err := cloneRepository(url, localPath, token)
-	
+	err := cloneRepositoryWithOptions(opts)
+
 	if err != nil {
 		result.Message = fmt.Sprintf("Clone failed: %s", err.Error())
-		NSLog("Clone() failed: %s", err.Error())
+		logError("clone failed", map[string]interface{}{"error": err.Error()})
 		return result
 	}
-	
+
 	// Extract repository info from the URL for the result
-	repoID := extractRepoID(url)
+	repoID := extractRepoID(opts.URL)
 	result.Success = true
 	result.Message = "Repository cloned successfully"
 	result.RepoID = repoID
 	result.RepoName = repoID // Could be enhanced to get actual name from metadata
-	
-	NSLog("Clone() succeeded: %s", result.Message)
+
+	logInfo("clone succeeded", map[string]interface{}{"url": opts.URL, "dest": opts.LocalPath, "repo_id": repoID})
 	return result
 }
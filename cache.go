@@ -0,0 +1,233 @@
+package MGitBridge
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gogitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+// Cache is a local bare-repo mirror cache, following the pattern used by
+// prow's git client: the first clone of a URL fetches a full bare mirror
+// under rootDir, and later clones of the same URL do a quick local fetch
+// against that mirror instead of re-downloading the whole history over the
+// network. This matters most on cellular, where iOS clients repeatedly
+// re-clone or switch working copies of the same repo.
+type Cache struct {
+	rootDir string
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// NewCache creates a Cache rooted at rootDir, creating the directory if needed
+func NewCache(rootDir string) *Cache {
+	os.MkdirAll(rootDir, 0755)
+	return &Cache{
+		rootDir: rootDir,
+		locks:   make(map[string]*sync.Mutex),
+	}
+}
+
+// lockFor returns the mutex serializing cache access for a given URL,
+// creating one on first use
+func (c *Cache) lockFor(url string) *sync.Mutex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lock, ok := c.locks[url]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.locks[url] = lock
+	}
+	return lock
+}
+
+// mirrorPath returns the on-disk path of the bare mirror for a URL
+func (c *Cache) mirrorPath(url string) string {
+	sum := sha1.Sum([]byte(url))
+	return filepath.Join(c.rootDir, hex.EncodeToString(sum[:])+".git")
+}
+
+// Clone clones opts.URL into opts.LocalPath, using (and maintaining) the
+// cached bare mirror for that URL rather than cloning directly from the
+// network every time.
+// opts is a *CloneOptions as used by CloneWithOptions; Cache.Clone only
+// reads URL, LocalPath, Token, PrivateKeyPEM, Passphrase, and
+// HostKeyFingerprint from it.
+func (c *Cache) Clone(opts *CloneOptions) *CloneResult {
+	logInfo("cache clone started", map[string]interface{}{"url": opts.URL, "dest": opts.LocalPath})
+
+	result := &CloneResult{
+		Success:   false,
+		Message:   "",
+		LocalPath: opts.LocalPath,
+	}
+
+	if opts.URL == "" {
+		result.Message = "Repository URL cannot be empty"
+		return result
+	}
+	if opts.LocalPath == "" {
+		result.Message = "Local path cannot be empty"
+		return result
+	}
+	if _, err := os.Stat(opts.LocalPath); !os.IsNotExist(err) {
+		result.Message = fmt.Sprintf("Destination path already exists: %s", opts.LocalPath)
+		return result
+	}
+
+	lock := c.lockFor(opts.URL)
+	lock.Lock()
+	defer lock.Unlock()
+
+	mirror := c.mirrorPath(opts.URL)
+	if err := c.updateMirror(opts.URL, mirror, opts); err != nil {
+		result.Message = fmt.Sprintf("error updating cache mirror: %s", err.Error())
+		return result
+	}
+
+	if _, err := git.PlainClone(opts.LocalPath, false, &git.CloneOptions{
+		URL:        mirror,
+		RemoteName: "origin",
+	}); err != nil {
+		result.Message = fmt.Sprintf("error cloning from cache mirror: %s", err.Error())
+		return result
+	}
+
+	if err := rewriteOrigin(opts.LocalPath, opts.URL); err != nil {
+		result.Message = fmt.Sprintf("error rewriting origin remote: %s", err.Error())
+		return result
+	}
+
+	// SSH remotes don't expose the HTTP metadata/LFS endpoints the MGit
+	// server serves alongside its HTTP git transport
+	if !isSSHURL(opts.URL) {
+		if err := setupMGitMetadata(opts.URL, opts.LocalPath, opts.Token); err != nil {
+			result.Message = err.Error()
+			return result
+		}
+	}
+
+	repoID := extractRepoID(opts.URL)
+	result.Success = true
+	result.Message = "Repository cloned successfully from cache"
+	result.RepoID = repoID
+	result.RepoName = repoID
+
+	logInfo("cache clone succeeded", map[string]interface{}{"message": result.Message})
+	return result
+}
+
+// cacheAuth builds the go-git transport.AuthMethod for a cache mirror
+// update, mirroring gitCloneWithOptions: key-authenticated for ssh:// /
+// git@ URLs, bearer-token HTTP Basic auth otherwise
+func cacheAuth(url string, opts *CloneOptions) (transport.AuthMethod, error) {
+	if isSSHURL(url) {
+		auth, err := gogitssh.NewPublicKeys("git", []byte(opts.PrivateKeyPEM), opts.Passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing private key: %w", err)
+		}
+		auth.HostKeyCallback = sshHostKeyCallback(opts.HostKeyFingerprint)
+		return auth, nil
+	}
+	return &githttp.BasicAuth{Username: "", Password: opts.Token}, nil
+}
+
+// updateMirror clones the bare mirror for url on first use, or fetches the
+// latest refs into it if it already exists
+func (c *Cache) updateMirror(url, mirror string, opts *CloneOptions) error {
+	auth, err := cacheAuth(url, opts)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(mirror); os.IsNotExist(err) {
+		logInfo("creating bare mirror", map[string]interface{}{"url": url})
+		_, err := git.PlainClone(mirror, true, &git.CloneOptions{
+			URL:  url,
+			Auth: auth,
+		})
+		return err
+	}
+
+	logDebug("fetching updates into mirror", map[string]interface{}{"url": url})
+	repo, err := git.PlainOpen(mirror)
+	if err != nil {
+		return fmt.Errorf("error opening cache mirror: %w", err)
+	}
+
+	err = repo.Fetch(&git.FetchOptions{
+		RemoteName: "origin",
+		Auth:       auth,
+		Force:      true,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("error fetching cache mirror: %w", err)
+	}
+
+	return nil
+}
+
+// rewriteOrigin points a freshly cloned repo's origin remote at the real
+// repository URL instead of the local mirror path it was cloned from
+func rewriteOrigin(repoPath, url string) error {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return fmt.Errorf("error opening repository: %w", err)
+	}
+
+	if err := repo.DeleteRemote("origin"); err != nil {
+		return fmt.Errorf("error removing local-mirror origin: %w", err)
+	}
+
+	_, err = repo.CreateRemote(&config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{url},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating origin remote: %w", err)
+	}
+
+	return nil
+}
+
+// Prune removes cached mirrors that haven't been modified in longer than maxAge
+func (c *Cache) Prune(maxAge time.Duration) error {
+	entries, err := os.ReadDir(c.rootDir)
+	if err != nil {
+		return fmt.Errorf("error reading cache directory: %w", err)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(c.rootDir, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		if info.ModTime().Before(cutoff) {
+			logInfo("pruning stale mirror", map[string]interface{}{"path": path})
+			if err := os.RemoveAll(path); err != nil {
+				return fmt.Errorf("error removing stale mirror %s: %w", path, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -10,35 +10,74 @@ import (
 	"strings"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gogitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
 )
 
 // cloneRepository implements the MGit clone functionality using go-git
 func cloneRepository(url, destination, token string) error {
+	return cloneRepositoryWithOptions(&CloneOptions{URL: url, LocalPath: destination, Token: token})
+}
+
+// cloneRepositoryWithOptions is the options-aware counterpart to
+// cloneRepository, used by CloneWithOptions
+func cloneRepositoryWithOptions(opts *CloneOptions) error {
 	// Create the destination directory if it doesn't exist
-	if err := os.MkdirAll(destination, 0755); err != nil {
+	if err := os.MkdirAll(opts.LocalPath, 0755); err != nil {
 		return fmt.Errorf("error creating destination directory: %w", err)
 	}
 
-	// Fetch repository metadata first
-	NSLog("Fetching repository metadata...")
-	repoInfo, err := fetchRepositoryInfo(url, token)
-	if err != nil {
-		return fmt.Errorf("error fetching repository metadata: %w", err)
+	// SSH remotes don't expose the HTTP metadata/LFS endpoints the MGit
+	// server serves alongside its HTTP git transport, so an SSH clone is
+	// just the raw git clone, key-authenticated instead of token-authenticated
+	if isSSHURL(opts.URL) {
+		logInfo("cloning git repository over ssh", map[string]interface{}{"url": opts.URL, "dest": opts.LocalPath})
+		if err := gitCloneWithOptions(opts); err != nil {
+			return fmt.Errorf("error cloning Git repository: %w", err)
+		}
+		logInfo("clone completed successfully", map[string]interface{}{"url": opts.URL, "dest": opts.LocalPath})
+		return nil
 	}
 
-	NSLog("Repository: %s, Access level: %s", repoInfo.Name, repoInfo.Access)
-
 	// Clone the Git data using go-git instead of system git
-	NSLog("Cloning Git repository with go-git...")
-	if err := gitCloneWithGoGit(url, destination, token); err != nil {
+	logInfo("cloning git repository", map[string]interface{}{"url": opts.URL, "dest": opts.LocalPath})
+	if err := gitCloneWithOptions(opts); err != nil {
 		return fmt.Errorf("error cloning Git repository: %w", err)
 	}
 
+	if err := setupMGitMetadata(opts.URL, opts.LocalPath, opts.Token); err != nil {
+		return err
+	}
+
+	logInfo("clone completed successfully", map[string]interface{}{"url": opts.URL, "dest": opts.LocalPath})
+	return nil
+}
+
+// setupMGitMetadata fetches repository metadata from the MGit server and
+// uses it to populate .mgit/mappings/hash_mappings.json, .mgit/config, and
+// the local LFS object store for an already-cloned git repository. It's
+// shared by cloneRepositoryWithOptions and Cache.Clone so every entry point
+// into this package produces a full MGit repository, not just a plain git one.
+func setupMGitMetadata(url, destination, token string) error {
+	logInfo("fetching repository metadata", map[string]interface{}{"url": url})
+	repoInfo, err := fetchRepositoryInfo(url, token)
+	if err != nil {
+		return fmt.Errorf("error fetching repository metadata: %w", err)
+	}
+	logInfo("repository metadata fetched", map[string]interface{}{"name": repoInfo.Name, "access": repoInfo.Access})
+
 	// Fetch and set up MGit metadata
-	NSLog("Setting up MGit metadata...")
+	logInfo("setting up mgit metadata", map[string]interface{}{"dest": destination})
 	if err := fetchMGitMetadata(url, destination, token); err != nil {
-		NSLog("Warning: Failed to fetch MGit metadata: %s", err.Error())
+		logWarn("failed to fetch mgit metadata", map[string]interface{}{"error": err.Error()})
+	}
+
+	// Materialize any Git LFS objects referenced by pointer files
+	if err := EnableLFS(destination); err != nil {
+		logWarn("failed to enable LFS", map[string]interface{}{"error": err.Error()})
+	} else if lfsResult := LFSFetchAll(destination, token); !lfsResult.Success {
+		logWarn("failed to fetch LFS objects", map[string]interface{}{"reason": lfsResult.Message})
 	}
 
 	// Set up MGit configuration
@@ -46,50 +85,72 @@ func cloneRepository(url, destination, token string) error {
 		return fmt.Errorf("error setting up MGit config: %w", err)
 	}
 
-	NSLog("Clone completed successfully")
 	return nil
 }
 
 // gitCloneWithGoGit performs the Git clone using go-git library (iOS compatible)
 func gitCloneWithGoGit(url, destination, token string) error {
-	NSLog("🔄 Starting Git clone: %s -> %s", url, destination)
-	
+	return gitCloneWithOptions(&CloneOptions{URL: url, LocalPath: destination, Token: token})
+}
+
+// gitCloneWithOptions is the options-aware counterpart to gitCloneWithGoGit,
+// mapping CloneOptions onto git.CloneOptions so a mobile caller can do a
+// shallow / single-branch / no-submodule clone over cellular
+func gitCloneWithOptions(opts *CloneOptions) error {
+	logDebug("starting git clone", map[string]interface{}{"url": opts.URL, "dest": opts.LocalPath})
+
 	// Clean up any existing destination
-	if err := os.RemoveAll(destination); err != nil {
-		NSLog("⚠️ Warning: Failed to clean destination: %s", err.Error())
+	if err := os.RemoveAll(opts.LocalPath); err != nil {
+		logWarn("failed to clean destination", map[string]interface{}{"dest": opts.LocalPath, "error": err.Error()})
 	}
-	
-	// Clone options
+
+	recurseSubmodules := git.DefaultSubmoduleRecursionDepth
+	if opts.NoSubmodules {
+		recurseSubmodules = git.NoRecurseSubmodules
+	}
+
 	cloneOptions := &git.CloneOptions{
-		URL:               url,
+		URL:               opts.URL,
 		RemoteName:        "origin",
-		ReferenceName:     "", // Clone default branch
-		SingleBranch:      false, // Clone all branches
-		NoCheckout:        false, // Do checkout working directory
-		Depth:             0, // Full clone, not shallow
-		RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
-		Auth: &githttp.BasicAuth{
+		SingleBranch:      opts.SingleBranch,
+		NoCheckout:        opts.NoCheckout,
+		Depth:             opts.Depth,
+		RecurseSubmodules: recurseSubmodules,
+	}
+	if opts.Branch != "" {
+		cloneOptions.ReferenceName = plumbing.NewBranchReferenceName(opts.Branch)
+	}
+
+	if isSSHURL(opts.URL) {
+		auth, err := gogitssh.NewPublicKeys("git", []byte(opts.PrivateKeyPEM), opts.Passphrase)
+		if err != nil {
+			return fmt.Errorf("error parsing private key: %w", err)
+		}
+		auth.HostKeyCallback = sshHostKeyCallback(opts.HostKeyFingerprint)
+		cloneOptions.Auth = auth
+	} else {
+		cloneOptions.Auth = &githttp.BasicAuth{
 			Username: "", // Empty username works with MGit server
-			Password: token,
-		},
+			Password: opts.Token,
+		}
 	}
-	
-	_, err := git.PlainClone(destination, false, cloneOptions)
+
+	_, err := git.PlainClone(opts.LocalPath, false, cloneOptions)
 	if err != nil {
-		NSLog("❌ Git clone failed: %s", err.Error())
+		logError("git clone failed", map[string]interface{}{"url": opts.URL, "error": err.Error()})
 		return fmt.Errorf("error cloning repository: %w", err)
 	}
-	
-	NSLog("✅ Git clone completed successfully")
+
+	logDebug("git clone completed successfully", map[string]interface{}{"dest": opts.LocalPath})
 	return nil
 }
 
 // fetchRepositoryInfo fetches information about the repository
 func fetchRepositoryInfo(url, token string) (*RepositoryInfo, error) {
 	infoURL := fmt.Sprintf("%s/info", url)
-	
-	NSLog("🆕 NEW fetchRepositoryInfo - requesting: %s", infoURL)
-	
+
+	logDebug("fetching repository info", map[string]interface{}{"url": infoURL})
+
 	req, err := http.NewRequest("GET", infoURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
@@ -113,10 +174,28 @@ func fetchRepositoryInfo(url, token string) (*RepositoryInfo, error) {
 	if err := json.NewDecoder(resp.Body).Decode(&repoInfo); err != nil {
 		return nil, fmt.Errorf("error parsing response: %w", err)
 	}
-	
+	repoInfo.Host = extractHost(url)
+
 	return &repoInfo, nil
 }
 
+// extractHost returns the bare host portion of a repository URL, stripping
+// any scheme, userinfo, port, or path so it can be recombined into either
+// an HTTPS or SSH clone URL
+func extractHost(rawURL string) string {
+	withoutScheme := rawURL
+	if idx := strings.Index(withoutScheme, "://"); idx != -1 {
+		withoutScheme = withoutScheme[idx+len("://"):]
+	}
+	if idx := strings.Index(withoutScheme, "@"); idx != -1 {
+		withoutScheme = withoutScheme[idx+1:]
+	}
+	if idx := strings.IndexAny(withoutScheme, "/:"); idx != -1 {
+		withoutScheme = withoutScheme[:idx]
+	}
+	return withoutScheme
+}
+
 // extractRepoID extracts the repository ID from a URL
 func extractRepoID(url string) string {
 	url = strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
@@ -184,7 +263,7 @@ func fetchMGitMetadata(url, destination, token string) error {
 		return fmt.Errorf("error writing nostr_mappings.json file: %w", err)
 	}
 	
-	NSLog("Successfully fetched and stored MGit metadata (%d mappings)", len(mappings))
+	logInfo("mgit metadata stored", map[string]interface{}{"mapping_count": len(mappings)})
 	return nil
 }
 
@@ -213,6 +292,6 @@ func setupMGitConfig(destination string, repoInfo *RepositoryInfo) error {
 		return fmt.Errorf("error writing MGit config: %w", err)
 	}
 	
-	NSLog("MGit config created successfully")
+	logDebug("mgit config created", map[string]interface{}{"dest": destination})
 	return nil
 }
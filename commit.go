@@ -0,0 +1,160 @@
+package MGitBridge
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Commit converts any LFS-tracked files to pointers (when EnableLFS has set
+// up the local object store), stages all changes, and creates a new commit, recording the resulting MGit hash in
+// .mgit/mappings/hash_mappings.json so the hash chain stays in sync with
+// the underlying git object graph
+func Commit(repoPath, message, authorName, authorEmail, pubkey string) *CommitResult {
+	logInfo("commit started", map[string]interface{}{"repo_path": repoPath, "author_email": authorEmail})
+
+	result := &CommitResult{
+		Success:   false,
+		Message:   "",
+		CommitMsg: message,
+	}
+
+	if repoPath == "" {
+		result.Message = "Repository path cannot be empty"
+		return result
+	}
+
+	if message == "" {
+		result.Message = "Commit message cannot be empty"
+		return result
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		result.Message = fmt.Sprintf("error opening repository: %s", err.Error())
+		return result
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		result.Message = fmt.Sprintf("error getting worktree: %s", err.Error())
+		return result
+	}
+
+	if isLFSEnabled(repoPath) {
+		if err := lfsCleanWorkingTree(repoPath); err != nil {
+			logWarn("failed to convert LFS-tracked files to pointers", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	if err := w.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		result.Message = fmt.Sprintf("error staging changes: %s", err.Error())
+		return result
+	}
+
+	sig := &object.Signature{
+		Name:  authorName,
+		Email: authorEmail,
+		When:  time.Now(),
+	}
+
+	gitHash, err := w.Commit(message, &git.CommitOptions{
+		Author:    sig,
+		Committer: sig,
+	})
+	if err != nil {
+		result.Message = fmt.Sprintf("error creating commit: %s", err.Error())
+		return result
+	}
+
+	mgitHash, err := recordHashMapping(repoPath, repo, gitHash.String(), pubkey, message)
+	if err != nil {
+		logWarn("failed to update hash_mappings.json", map[string]interface{}{"error": err.Error()})
+	}
+
+	result.Success = true
+	result.Message = "Commit created successfully"
+	result.GitHash = gitHash.String()
+	result.MGitHash = mgitHash
+
+	logInfo("commit succeeded", map[string]interface{}{"git_hash": gitHash.String()})
+	return result
+}
+
+// RecordSignature stores the nostr signature for a commit's already-recorded
+// MGit hash. Commit() never has access to the author's private key, so
+// signing happens client-side: the iOS host calls Commit(), signs the
+// returned MGitHash with the user's nostr key, then calls RecordSignature so
+// VerifyChain can later confirm the chain hasn't been tampered with.
+func RecordSignature(repoPath, gitHash, signatureHex string) error {
+	if repoPath == "" {
+		return fmt.Errorf("repository path cannot be empty")
+	}
+	if gitHash == "" {
+		return fmt.Errorf("git hash cannot be empty")
+	}
+	if signatureHex == "" {
+		return fmt.Errorf("signature cannot be empty")
+	}
+
+	mappings, err := loadHashMappings(repoPath)
+	if err != nil {
+		return err
+	}
+
+	mapping := findHashMapping(mappings, gitHash)
+	if mapping == nil {
+		return fmt.Errorf("no MGit hash mapping found for commit %s", gitHash)
+	}
+
+	mapping.Signature = signatureHex
+
+	if err := saveHashMappings(repoPath, mappings); err != nil {
+		return err
+	}
+
+	logInfo("signature recorded", map[string]interface{}{"git_hash": gitHash})
+	return nil
+}
+
+// recordHashMapping computes the MGit hash for a newly created commit and
+// appends it to .mgit/mappings/hash_mappings.json
+func recordHashMapping(repoPath string, repo *git.Repository, gitHash, pubkey, message string) (string, error) {
+	mappings, err := loadHashMappings(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	commit, err := repo.CommitObject(plumbing.NewHash(gitHash))
+	if err != nil {
+		return "", fmt.Errorf("error loading commit object: %w", err)
+	}
+
+	var parentMGitHashes []string
+	for _, parentHash := range commit.ParentHashes {
+		parent := findHashMapping(mappings, parentHash.String())
+		if parent != nil {
+			parentMGitHashes = append(parentMGitHashes, parent.MGitHash)
+		}
+	}
+
+	mgitHash := computeMGitHash(gitHash, parentMGitHashes, pubkey)
+
+	mappings = append(mappings, HashMapping{
+		GitHash:          gitHash,
+		MGitHash:         mgitHash,
+		ParentMGitHashes: parentMGitHashes,
+		AuthorPubkey:     pubkey,
+		Message:          message,
+		Timestamp:        time.Now().UTC().Format(time.RFC3339),
+	})
+
+	if err := saveHashMappings(repoPath, mappings); err != nil {
+		return "", err
+	}
+
+	return mgitHash, nil
+}
@@ -0,0 +1,532 @@
+package MGitBridge
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// sha256Hex returns the hex-encoded sha256 digest of data, used to derive
+// the LFS object id for files not yet tracked as pointers
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// lfsPointerPrefix is the signature line at the start of every Git LFS
+// pointer file, per https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md
+const lfsPointerPrefix = "version https://git-lfs.github.com/spec/v1"
+
+// lfsPointer describes the oid/size pair parsed out of a pointer file
+type lfsPointer struct {
+	Path string
+	OID  string
+	Size int64
+}
+
+// lfsBatchObject mirrors a single entry in an LFS batch API request/response
+type lfsBatchObject struct {
+	OID     string               `json:"oid"`
+	Size    int64                `json:"size"`
+	Actions map[string]lfsAction `json:"actions,omitempty"`
+	Error   *lfsBatchObjectError `json:"error,omitempty"`
+}
+
+type lfsBatchObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lfsAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+type lfsBatchRequest struct {
+	Operation string           `json:"operation"`
+	Transfers []string         `json:"transfers"`
+	Objects   []lfsBatchObject `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Transfer string           `json:"transfer"`
+	Objects  []lfsBatchObject `json:"objects"`
+}
+
+// EnableLFS marks a repository as LFS-aware by creating the local object
+// store that LFSFetchAll, Commit, and Push use for LFS object contents
+func EnableLFS(repoPath string) error {
+	objectsDir := filepath.Join(repoPath, ".git", "lfs", "objects")
+	if err := os.MkdirAll(objectsDir, 0755); err != nil {
+		return fmt.Errorf("error creating .git/lfs/objects: %w", err)
+	}
+
+	logDebug("lfs enabled", map[string]interface{}{"repo_path": repoPath})
+	return nil
+}
+
+// isLFSEnabled reports whether EnableLFS has already set up the local store
+func isLFSEnabled(repoPath string) bool {
+	_, err := os.Stat(filepath.Join(repoPath, ".git", "lfs", "objects"))
+	return err == nil
+}
+
+// lfsObjectPath returns the on-disk path for an LFS object, following the
+// same oid[0:2]/oid[2:4]/oid layout as the reference git-lfs client
+func lfsObjectPath(repoPath, oid string) string {
+	if len(oid) < 4 {
+		return filepath.Join(repoPath, ".git", "lfs", "objects", oid)
+	}
+	return filepath.Join(repoPath, ".git", "lfs", "objects", oid[0:2], oid[2:4], oid)
+}
+
+// parseLFSPointer parses the contents of a candidate pointer file, returning
+// ok=false if it isn't a valid Git LFS pointer
+func parseLFSPointer(data []byte) (oid string, size int64, ok bool) {
+	text := string(data)
+	if !strings.HasPrefix(text, lfsPointerPrefix) {
+		return "", 0, false
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "oid sha256:"):
+			oid = strings.TrimPrefix(line, "oid sha256:")
+		case strings.HasPrefix(line, "size "):
+			if n, err := strconv.ParseInt(strings.TrimPrefix(line, "size "), 10, 64); err == nil {
+				size = n
+			}
+		}
+	}
+
+	return oid, size, oid != "" && size > 0
+}
+
+// findLFSPointers walks the working tree looking for files whose contents
+// are Git LFS pointers (small text files, so this is safe to read in full)
+func findLFSPointers(repoPath string) ([]lfsPointer, error) {
+	var pointers []lfsPointer
+
+	err := filepath.Walk(repoPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" || info.Name() == ".mgit" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// Pointer files are always small; skip anything too large to be one
+		if info.Size() > 1024 {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+
+		if oid, size, ok := parseLFSPointer(data); ok {
+			pointers = append(pointers, lfsPointer{Path: path, OID: oid, Size: size})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error walking working tree for LFS pointers: %w", err)
+	}
+
+	return pointers, nil
+}
+
+// lfsBatch issues an LFS batch API request against <remote>/info/lfs/objects/batch
+func lfsBatch(remoteURL, token, operation string, pointers []lfsPointer) (*lfsBatchResponse, error) {
+	objects := make([]lfsBatchObject, len(pointers))
+	for i, p := range pointers {
+		objects[i] = lfsBatchObject{OID: p.OID, Size: p.Size}
+	}
+
+	reqBody, err := json.Marshal(lfsBatchRequest{
+		Operation: operation,
+		Transfers: []string{"basic"},
+		Objects:   objects,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding LFS batch request: %w", err)
+	}
+
+	batchURL := strings.TrimSuffix(remoteURL, "/") + "/info/lfs/objects/batch"
+	req, err := http.NewRequest("POST", batchURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("error creating LFS batch request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Accept", "application/vnd.git-lfs+json")
+	req.Header.Set("Content-Type", "application/vnd.git-lfs+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error calling LFS batch endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("LFS batch endpoint returned status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var batchResp lfsBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batchResp); err != nil {
+		return nil, fmt.Errorf("error parsing LFS batch response: %w", err)
+	}
+
+	return &batchResp, nil
+}
+
+// LFSFetchAll downloads every LFS object referenced by pointer files in the
+// working tree and materializes their real contents on disk
+func LFSFetchAll(repoPath, token string) *LFSResult {
+	logInfo("lfs fetch all started", map[string]interface{}{"repo_path": repoPath})
+
+	result := &LFSResult{Success: false}
+
+	if repoPath == "" {
+		result.Message = "Repository path cannot be empty"
+		return result
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		result.Message = fmt.Sprintf("error opening repository: %s", err.Error())
+		return result
+	}
+
+	remote, err := repo.Remote("origin")
+	if err != nil || len(remote.Config().URLs) == 0 {
+		result.Message = "error resolving origin remote URL"
+		return result
+	}
+	remoteURL := remote.Config().URLs[0]
+
+	pointers, err := findLFSPointers(repoPath)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	if len(pointers) == 0 {
+		result.Success = true
+		result.Message = "No LFS pointers found"
+		return result
+	}
+
+	batchResp, err := lfsBatch(remoteURL, token, "download", pointers)
+	if err != nil {
+		result.Message = fmt.Sprintf("LFS batch request failed: %s", err.Error())
+		return result
+	}
+
+	byOID := make(map[string]lfsPointer, len(pointers))
+	for _, p := range pointers {
+		byOID[p.OID] = p
+	}
+
+	var fetched int
+	var bytesFetched int64
+	for _, obj := range batchResp.Objects {
+		if obj.Error != nil {
+			logWarn("lfs object reported server error", map[string]interface{}{"oid": obj.OID, "error": obj.Error.Message})
+			continue
+		}
+
+		download, ok := obj.Actions["download"]
+		if !ok {
+			continue
+		}
+
+		pointer, ok := byOID[obj.OID]
+		if !ok {
+			continue
+		}
+
+		n, err := downloadLFSObject(repoPath, pointer, download, token)
+		if err != nil {
+			logWarn("lfs object download failed", map[string]interface{}{"oid": obj.OID, "error": err.Error()})
+			continue
+		}
+
+		fetched++
+		bytesFetched += n
+	}
+
+	result.Success = true
+	result.Message = "LFS fetch completed"
+	result.ObjectsFetched = fetched
+	result.BytesFetched = bytesFetched
+
+	logInfo("lfs fetch all succeeded", map[string]interface{}{"objects_fetched": fetched, "bytes_fetched": bytesFetched})
+	return result
+}
+
+// downloadLFSObject fetches a single LFS object into .git/lfs/objects and
+// writes its real contents over the pointer file in the working tree. The
+// index is left untouched, still matching the pointer blob committed at
+// HEAD rather than the materialized bytes now on disk — go-git has no
+// clean/smudge filter support, so Status() will report the file as
+// modified in the working tree, the same as running real git-lfs without
+// its filters installed. Both copies are streamed rather than buffered
+// whole in memory, since LFS objects are routinely hundreds of megabytes.
+func downloadLFSObject(repoPath string, pointer lfsPointer, download lfsAction, token string) (int64, error) {
+	req, err := http.NewRequest("GET", download.Href, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error creating download request: %w", err)
+	}
+	for k, v := range download.Header {
+		req.Header.Set(k, v)
+	}
+	if req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error downloading object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("download returned status %d", resp.StatusCode)
+	}
+
+	objectPath := lfsObjectPath(repoPath, pointer.OID)
+	if err := os.MkdirAll(filepath.Dir(objectPath), 0755); err != nil {
+		return 0, fmt.Errorf("error creating LFS object directory: %w", err)
+	}
+
+	objectFile, err := os.Create(objectPath)
+	if err != nil {
+		return 0, fmt.Errorf("error creating LFS object file: %w", err)
+	}
+	defer objectFile.Close()
+
+	n, err := io.Copy(objectFile, resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("error writing LFS object: %w", err)
+	}
+
+	workingFile, err := os.Create(pointer.Path)
+	if err != nil {
+		return 0, fmt.Errorf("error creating working tree file: %w", err)
+	}
+	defer workingFile.Close()
+
+	if _, err := objectFile.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("error seeking LFS object file: %w", err)
+	}
+	if _, err := io.Copy(workingFile, objectFile); err != nil {
+		return 0, fmt.Errorf("error materializing working tree file: %w", err)
+	}
+
+	return n, nil
+}
+
+// lfsTrackedFiles returns the working tree files matched by a `filter=lfs`
+// pattern in .gitattributes, ready to be uploaded before a push. Each
+// pattern is expanded with filepath.Glob relative to the repository root,
+// so a pattern without a slash (e.g. `*.psd`) matches top-level files only;
+// files in subdirectories are not matched, and `**` is not supported.
+func lfsTrackedFiles(repoPath string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(repoPath, ".gitattributes"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading .gitattributes: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		isLFS := false
+		for _, attr := range fields[1:] {
+			if attr == "filter=lfs" {
+				isLFS = true
+				break
+			}
+		}
+		if !isLFS {
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(repoPath, fields[0]))
+		if err != nil {
+			continue
+		}
+		files = append(files, matches...)
+	}
+
+	return files, nil
+}
+
+// lfsCleanWorkingTree rewrites every .gitattributes-tracked file that isn't
+// already an LFS pointer into pointer text, storing its real bytes in the
+// local object store first. Commit calls this before staging so the bytes
+// that actually land in the git object database are the pointer, not the
+// raw binary.
+func lfsCleanWorkingTree(repoPath string) error {
+	files, err := lfsTrackedFiles(repoPath)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if _, _, ok := parseLFSPointer(data); ok {
+			continue // already a pointer
+		}
+
+		oid := sha256Hex(data)
+		objectPath := lfsObjectPath(repoPath, oid)
+		if err := os.MkdirAll(filepath.Dir(objectPath), 0755); err != nil {
+			return fmt.Errorf("error creating LFS object directory: %w", err)
+		}
+		if err := os.WriteFile(objectPath, data, 0644); err != nil {
+			return fmt.Errorf("error storing LFS object: %w", err)
+		}
+
+		pointerText := fmt.Sprintf("%s\noid sha256:%s\nsize %d\n", lfsPointerPrefix, oid, len(data))
+		if err := os.WriteFile(path, []byte(pointerText), 0644); err != nil {
+			return fmt.Errorf("error writing LFS pointer for %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// lfsUploadAll uploads every .gitattributes-tracked LFS object the remote
+// doesn't already have. lfsCleanWorkingTree has already swapped tracked
+// files for pointer text by commit time, so this reads the real bytes back
+// out of the local object store rather than the (now pointer-only) working
+// tree, and never touches the working tree itself.
+func lfsUploadAll(repoPath, token string) error {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return fmt.Errorf("error opening repository: %w", err)
+	}
+
+	remote, err := repo.Remote("origin")
+	if err != nil || len(remote.Config().URLs) == 0 {
+		return fmt.Errorf("error resolving origin remote URL")
+	}
+	remoteURL := remote.Config().URLs[0]
+
+	files, err := lfsTrackedFiles(repoPath)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	var pointers []lfsPointer
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		oid, size, ok := parseLFSPointer(data)
+		if !ok {
+			continue // not yet converted to a pointer; nothing to upload
+		}
+		pointers = append(pointers, lfsPointer{Path: path, OID: oid, Size: size})
+	}
+	if len(pointers) == 0 {
+		return nil
+	}
+
+	batchResp, err := lfsBatch(remoteURL, token, "upload", pointers)
+	if err != nil {
+		return fmt.Errorf("LFS upload batch request failed: %w", err)
+	}
+
+	for _, obj := range batchResp.Objects {
+		upload, ok := obj.Actions["upload"]
+		if !ok {
+			continue // server already has this object
+		}
+
+		objectPath := lfsObjectPath(repoPath, obj.OID)
+		size, err := fileSize(objectPath)
+		if err != nil {
+			return fmt.Errorf("error statting local LFS object %s: %w", obj.OID, err)
+		}
+
+		if err := uploadLFSObject(upload, objectPath, size, token); err != nil {
+			return fmt.Errorf("error uploading LFS object %s: %w", obj.OID, err)
+		}
+	}
+
+	return nil
+}
+
+// fileSize returns the size in bytes of the file at path
+func fileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// uploadLFSObject PUTs a single object's bytes to the href returned by the
+// batch API, streaming the file from disk rather than buffering it whole in
+// memory, since LFS objects are routinely hundreds of megabytes
+func uploadLFSObject(upload lfsAction, objectPath string, size int64, token string) error {
+	file, err := os.Open(objectPath)
+	if err != nil {
+		return fmt.Errorf("error opening local LFS object: %w", err)
+	}
+	defer file.Close()
+
+	req, err := http.NewRequest("PUT", upload.Href, file)
+	if err != nil {
+		return fmt.Errorf("error creating upload request: %w", err)
+	}
+	req.ContentLength = size
+	for k, v := range upload.Header {
+		req.Header.Set(k, v)
+	}
+	if req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error uploading object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("upload returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
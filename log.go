@@ -0,0 +1,81 @@
+package MGitBridge
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// Log returns the most recent commits on the current branch, enriched with
+// MGit hash information where available. A limit of 0 or less returns all
+// commits reachable from HEAD.
+func Log(repoPath string, limit int) []MCommitInfo {
+	logInfo("log started", map[string]interface{}{"repo_path": repoPath, "limit": limit})
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		logError("log failed", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		logError("log failed", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+
+	commitIter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		logError("log failed", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+	defer commitIter.Close()
+
+	mappings, err := loadHashMappings(repoPath)
+	if err != nil {
+		logWarn("failed to load hash_mappings.json", map[string]interface{}{"error": err.Error()})
+	}
+
+	var commits []MCommitInfo
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		if limit > 0 && len(commits) >= limit {
+			return storer.ErrStop
+		}
+
+		var parentHashes []string
+		for _, parent := range c.ParentHashes {
+			parentHashes = append(parentHashes, parent.String())
+		}
+
+		mgitHash := ""
+		if m := findHashMapping(mappings, c.Hash.String()); m != nil {
+			mgitHash = m.MGitHash
+		}
+
+		commits = append(commits, MCommitInfo{
+			MGitHash: mgitHash,
+			GitHash:  c.Hash.String(),
+			Message:  c.Message,
+			Author: MGitSignature{
+				Name:  c.Author.Name,
+				Email: c.Author.Email,
+				When:  c.Author.When.String(),
+			},
+			Committer: MGitSignature{
+				Name:  c.Committer.Name,
+				Email: c.Committer.Email,
+				When:  c.Committer.When.String(),
+			},
+			ParentHashes: parentHashes,
+			TreeHash:     c.TreeHash.String(),
+		})
+		return nil
+	})
+	if err != nil {
+		logError("log failed", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+
+	logInfo("log succeeded", map[string]interface{}{"commit_count": len(commits)})
+	return commits
+}
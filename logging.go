@@ -0,0 +1,140 @@
+package MGitBridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logger receives MGitBridge's structured log records. An iOS host installs
+// an adapter via SetLogger that forwards records to os_log/OSLog with the
+// right subsystem/category and level, instead of scraping NSLog/log.Printf
+// output that can't be filtered from Swift.
+type Logger interface {
+	Debug(message string, fields map[string]interface{})
+	Info(message string, fields map[string]interface{})
+	Warn(message string, fields map[string]interface{})
+	Error(message string, fields map[string]interface{})
+}
+
+type logLevel int
+
+const (
+	levelDebug logLevel = iota
+	levelInfo
+	levelWarn
+	levelError
+)
+
+var (
+	loggerMu     sync.RWMutex
+	activeLogger Logger = newJSONLogger(os.Stderr)
+	activeLevel  logLevel
+)
+
+// SetLogger installs a custom Logger, e.g. an iOS os_log adapter. Passing
+// nil restores the default JSON-line logger.
+func SetLogger(l Logger) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	if l == nil {
+		l = newJSONLogger(os.Stderr)
+	}
+	activeLogger = l
+}
+
+// SetLogLevel sets the minimum level forwarded to the active Logger
+// ("debug", "info", "warn", or "error"); unrecognized values are ignored,
+// so verbose network tracing can be toggled from Swift without recompiling.
+func SetLogLevel(level string) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+
+	switch strings.ToLower(level) {
+	case "debug":
+		activeLevel = levelDebug
+	case "info":
+		activeLevel = levelInfo
+	case "warn", "warning":
+		activeLevel = levelWarn
+	case "error":
+		activeLevel = levelError
+	}
+}
+
+func logDebug(message string, fields map[string]interface{}) { logAt(levelDebug, message, fields) }
+func logInfo(message string, fields map[string]interface{})  { logAt(levelInfo, message, fields) }
+func logWarn(message string, fields map[string]interface{})  { logAt(levelWarn, message, fields) }
+func logError(message string, fields map[string]interface{}) { logAt(levelError, message, fields) }
+
+func logAt(level logLevel, message string, fields map[string]interface{}) {
+	loggerMu.RLock()
+	logger, minLevel := activeLogger, activeLevel
+	loggerMu.RUnlock()
+
+	if level < minLevel {
+		return
+	}
+
+	switch level {
+	case levelDebug:
+		logger.Debug(message, fields)
+	case levelInfo:
+		logger.Info(message, fields)
+	case levelWarn:
+		logger.Warn(message, fields)
+	case levelError:
+		logger.Error(message, fields)
+	}
+}
+
+// jsonLogger is the default Logger, used in tests and whenever no iOS host
+// adapter has been installed. It writes one JSON object per line.
+type jsonLogger struct {
+	out io.Writer
+	mu  sync.Mutex
+}
+
+func newJSONLogger(out io.Writer) *jsonLogger {
+	return &jsonLogger{out: out}
+}
+
+func (j *jsonLogger) Debug(message string, fields map[string]interface{}) {
+	j.write("debug", message, fields)
+}
+
+func (j *jsonLogger) Info(message string, fields map[string]interface{}) {
+	j.write("info", message, fields)
+}
+
+func (j *jsonLogger) Warn(message string, fields map[string]interface{}) {
+	j.write("warn", message, fields)
+}
+
+func (j *jsonLogger) Error(message string, fields map[string]interface{}) {
+	j.write("error", message, fields)
+}
+
+func (j *jsonLogger) write(level, message string, fields map[string]interface{}) {
+	record := map[string]interface{}{
+		"level":   level,
+		"message": message,
+		"time":    time.Now().UTC().Format(time.RFC3339),
+	}
+	if len(fields) > 0 {
+		record["fields"] = fields
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	fmt.Fprintln(j.out, string(data))
+}
@@ -0,0 +1,87 @@
+package MGitBridge
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// hashMappingsPath returns the path to .mgit/mappings/hash_mappings.json for a repo
+func hashMappingsPath(repoPath string) string {
+	return filepath.Join(repoPath, ".mgit", "mappings", "hash_mappings.json")
+}
+
+// loadHashMappings reads .mgit/mappings/hash_mappings.json, returning an empty
+// slice if the file does not exist yet
+func loadHashMappings(repoPath string) ([]HashMapping, error) {
+	path := hashMappingsPath(repoPath)
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []HashMapping{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading hash_mappings.json: %w", err)
+	}
+
+	var mappings []HashMapping
+	if err := json.Unmarshal(data, &mappings); err != nil {
+		return nil, fmt.Errorf("error parsing hash_mappings.json: %w", err)
+	}
+
+	return mappings, nil
+}
+
+// saveHashMappings writes the mappings slice back to .mgit/mappings/hash_mappings.json
+func saveHashMappings(repoPath string, mappings []HashMapping) error {
+	path := hashMappingsPath(repoPath)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating .mgit/mappings directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(mappings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing hash_mappings.json: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing hash_mappings.json: %w", err)
+	}
+
+	return nil
+}
+
+// findHashMapping looks up the mapping entry for a given git commit hash
+func findHashMapping(mappings []HashMapping, gitHash string) *HashMapping {
+	for i := range mappings {
+		if mappings[i].GitHash == gitHash {
+			return &mappings[i]
+		}
+	}
+	return nil
+}
+
+// computeMGitHashBytes derives the raw MGit hash digest for a commit as
+// sha256(git_hash || parent_mgit_hashes... || author_pubkey)
+func computeMGitHashBytes(gitHash string, parentMGitHashes []string, authorPubkey string) [32]byte {
+	h := sha256.New()
+	h.Write([]byte(gitHash))
+	for _, parent := range parentMGitHashes {
+		h.Write([]byte(parent))
+	}
+	h.Write([]byte(authorPubkey))
+	var sum [32]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// computeMGitHash derives the hex-encoded MGit hash for a commit as
+// sha256(git_hash || parent_mgit_hashes... || author_pubkey)
+func computeMGitHash(gitHash string, parentMGitHashes []string, authorPubkey string) string {
+	sum := computeMGitHashBytes(gitHash, parentMGitHashes, authorPubkey)
+	return hex.EncodeToString(sum[:])
+}
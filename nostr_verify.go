@@ -0,0 +1,49 @@
+package MGitBridge
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+// verifyNostrSignature checks a schnorr/secp256k1 signature (as used by
+// Nostr, NIP-01) over an MGit hash. pubkeyHex may be a 33-byte compressed
+// key or a 32-byte x-only key; BIP-340 verification only ever uses the
+// x-coordinate, so both forms are accepted the same way.
+func verifyNostrSignature(mgitHash [32]byte, pubkeyHex, signatureHex string) error {
+	pubkeyBytes, err := hex.DecodeString(pubkeyHex)
+	if err != nil {
+		return fmt.Errorf("error decoding author pubkey: %w", err)
+	}
+
+	switch len(pubkeyBytes) {
+	case 32:
+		// already x-only
+	case 33:
+		pubkeyBytes = pubkeyBytes[1:]
+	default:
+		return fmt.Errorf("author pubkey must be 32 or 33 bytes, got %d", len(pubkeyBytes))
+	}
+
+	pubkey, err := schnorr.ParsePubKey(pubkeyBytes)
+	if err != nil {
+		return fmt.Errorf("error parsing author pubkey: %w", err)
+	}
+
+	sigBytes, err := hex.DecodeString(signatureHex)
+	if err != nil {
+		return fmt.Errorf("error decoding signature: %w", err)
+	}
+
+	sig, err := schnorr.ParseSignature(sigBytes)
+	if err != nil {
+		return fmt.Errorf("error parsing signature: %w", err)
+	}
+
+	if !sig.Verify(mgitHash[:], pubkey) {
+		return fmt.Errorf("schnorr signature verification failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package MGitBridge
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+func TestVerifyNostrSignature(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+	pubkeyXOnly := hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+	pubkeyCompressed := hex.EncodeToString(privKey.PubKey().SerializeCompressed())
+
+	var hash [32]byte
+	copy(hash[:], []byte("0123456789abcdef0123456789abcde"))
+
+	sig, err := schnorr.Sign(privKey, hash[:])
+	if err != nil {
+		t.Fatalf("error signing: %v", err)
+	}
+	sigHex := hex.EncodeToString(sig.Serialize())
+
+	var otherHash [32]byte
+	copy(otherHash[:], []byte("fedcba9876543210fedcba987654321"))
+
+	tests := []struct {
+		name      string
+		mgitHash  [32]byte
+		pubkeyHex string
+		sigHex    string
+		wantErr   bool
+	}{
+		{"valid with x-only pubkey", hash, pubkeyXOnly, sigHex, false},
+		{"valid with compressed pubkey", hash, pubkeyCompressed, sigHex, false},
+		{"wrong hash", otherHash, pubkeyXOnly, sigHex, true},
+		{"malformed pubkey hex", hash, "not-hex", sigHex, true},
+		{"wrong-length pubkey", hash, "aabb", sigHex, true},
+		{"malformed signature hex", hash, pubkeyXOnly, "not-hex", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyNostrSignature(tt.mgitHash, tt.pubkeyHex, tt.sigHex)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyNostrSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package MGitBridge
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// Pull fetches and merges remote changes into the current branch
+func Pull(repoPath, token string) *PullResult {
+	logInfo("pull started", map[string]interface{}{"repo_path": repoPath})
+
+	result := &PullResult{
+		Success: false,
+		Message: "",
+	}
+
+	if repoPath == "" {
+		result.Message = "Repository path cannot be empty"
+		return result
+	}
+
+	if token == "" {
+		result.Message = "Authentication token cannot be empty"
+		return result
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		result.Message = fmt.Sprintf("error opening repository: %s", err.Error())
+		return result
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		result.Message = fmt.Sprintf("error getting worktree: %s", err.Error())
+		return result
+	}
+
+	beforeHead, err := repo.Head()
+	if err != nil {
+		result.Message = fmt.Sprintf("error reading HEAD: %s", err.Error())
+		return result
+	}
+
+	err = w.Pull(&git.PullOptions{
+		RemoteName: "origin",
+		Auth: &githttp.BasicAuth{
+			Username: "",
+			Password: token,
+		},
+	})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		result.Success = true
+		result.Message = "Already up to date"
+		result.Changes = 0
+		return result
+	}
+	if err != nil {
+		result.Message = fmt.Sprintf("error pulling from remote: %s", err.Error())
+		logError("pull failed", map[string]interface{}{"error": err.Error()})
+		return result
+	}
+
+	afterHead, err := repo.Head()
+	if err != nil {
+		result.Message = fmt.Sprintf("error reading HEAD after pull: %s", err.Error())
+		return result
+	}
+
+	changes, err := countCommitsBetween(repo, beforeHead.Hash().String(), afterHead.Hash().String())
+	if err != nil {
+		logWarn("failed to count pulled commits", map[string]interface{}{"error": err.Error()})
+	}
+
+	result.Success = true
+	result.Message = "Pull completed successfully"
+	result.Changes = changes
+
+	logInfo("pull succeeded", map[string]interface{}{"changes": changes})
+	return result
+}
+
+// countCommitsBetween counts how many commits separate "to" from "from" by
+// walking the commit graph starting at "to"
+func countCommitsBetween(repo *git.Repository, from, to string) (int, error) {
+	if from == to {
+		return 0, nil
+	}
+
+	commitIter, err := repo.Log(&git.LogOptions{From: plumbing.NewHash(to)})
+	if err != nil {
+		return 0, fmt.Errorf("error walking commit log: %w", err)
+	}
+	defer commitIter.Close()
+
+	count := 0
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		if c.Hash.String() == from {
+			return storer.ErrStop
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return count, fmt.Errorf("error counting commits: %w", err)
+	}
+
+	return count, nil
+}
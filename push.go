@@ -0,0 +1,73 @@
+package MGitBridge
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// Push pushes committed changes to the remote MGit server
+func Push(repoPath, token string) *PushResult {
+	logInfo("push started", map[string]interface{}{"repo_path": repoPath})
+
+	result := &PushResult{
+		Success: false,
+		Message: "",
+	}
+
+	if repoPath == "" {
+		result.Message = "Repository path cannot be empty"
+		return result
+	}
+
+	if token == "" {
+		result.Message = "Authentication token cannot be empty"
+		return result
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		result.Message = fmt.Sprintf("error opening repository: %s", err.Error())
+		return result
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		result.Message = fmt.Sprintf("error reading HEAD: %s", err.Error())
+		return result
+	}
+
+	if isLFSEnabled(repoPath) {
+		if err := lfsUploadAll(repoPath, token); err != nil {
+			logWarn("failed to upload LFS objects", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	err = repo.Push(&git.PushOptions{
+		RemoteName: "origin",
+		Auth: &githttp.BasicAuth{
+			Username: "",
+			Password: token,
+		},
+	})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		result.Success = true
+		result.Message = "Already up to date"
+		result.CommitHash = head.Hash().String()
+		return result
+	}
+	if err != nil {
+		result.Message = fmt.Sprintf("error pushing to remote: %s", err.Error())
+		logError("push failed", map[string]interface{}{"error": err.Error()})
+		return result
+	}
+
+	result.Success = true
+	result.Message = "Push completed successfully"
+	result.CommitHash = head.Hash().String()
+
+	logInfo("push succeeded", map[string]interface{}{"commit_hash": head.Hash().String()})
+	return result
+}
@@ -0,0 +1,61 @@
+package MGitBridge
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// isSSHURL reports whether a repository URL should be cloned over SSH,
+// i.e. it uses the ssh:// scheme or the scp-like git@host:path form
+func isSSHURL(url string) bool {
+	return strings.HasPrefix(url, "ssh://") || strings.HasPrefix(url, "git@")
+}
+
+// CloneURL returns the repository's clone URL for the given transport
+// protocol ("ssh" or "https", defaulting to "https"), so the same
+// repository record can be cloned either way from Swift
+func (r *RepositoryInfo) CloneURL(protocol string) string {
+	if protocol == "ssh" {
+		return fmt.Sprintf("ssh://git@%s/%s", r.Host, r.ID)
+	}
+	return fmt.Sprintf("https://%s/%s", r.Host, r.ID)
+}
+
+// CloneWithSSH clones an MGit repository over SSH, authenticating with a
+// PEM-encoded private key instead of a bearer token. It is a thin wrapper
+// around CloneWithOptions, which detects the ssh:// / git@ URL via
+// isSSHURL and dispatches to the SSH-authenticated clone path itself.
+//
+// CloneWithSSH has no way to pin the server's host key; callers that need
+// that guarantee should call CloneWithOptions directly with
+// CloneOptions.HostKeyFingerprint set.
+func CloneWithSSH(url, localPath, privateKeyPEM, passphrase string) *CloneResult {
+	return CloneWithOptions(&CloneOptions{
+		URL:           url,
+		LocalPath:     localPath,
+		PrivateKeyPEM: privateKeyPEM,
+		Passphrase:    passphrase,
+	})
+}
+
+// sshHostKeyCallback builds the ssh.HostKeyCallback for an SSH clone. When
+// fingerprint is set, it pins the server to that exact SHA256 fingerprint
+// (OpenSSH's "SHA256:<base64>" form) and rejects anything else; an empty
+// fingerprint falls back to accepting any host key, since iOS apps don't
+// have a ~/.ssh/known_hosts file to verify against.
+func sshHostKeyCallback(fingerprint string) ssh.HostKeyCallback {
+	if fingerprint == "" {
+		return ssh.InsecureIgnoreHostKey()
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		got := ssh.FingerprintSHA256(key)
+		if got != fingerprint {
+			return fmt.Errorf("host key fingerprint mismatch for %s: got %s, want %s", hostname, got, fingerprint)
+		}
+		return nil
+	}
+}
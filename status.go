@@ -0,0 +1,68 @@
+package MGitBridge
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// Status reports the working tree status of an MGit repository
+func Status(repoPath string) *StatusResult {
+	logInfo("status started", map[string]interface{}{"repo_path": repoPath})
+
+	result := &StatusResult{
+		Success: false,
+		Message: "",
+	}
+
+	if repoPath == "" {
+		result.Message = "Repository path cannot be empty"
+		return result
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		result.Message = fmt.Sprintf("error opening repository: %s", err.Error())
+		return result
+	}
+
+	head, err := repo.Head()
+	if err == nil {
+		result.Branch = head.Name().Short()
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		result.Message = fmt.Sprintf("error getting worktree: %s", err.Error())
+		return result
+	}
+
+	status, err := w.Status()
+	if err != nil {
+		result.Message = fmt.Sprintf("error getting status: %s", err.Error())
+		return result
+	}
+
+	for path, fileStatus := range status {
+		switch fileStatus.Staging {
+		case git.Added, git.Modified, git.Renamed, git.Copied:
+			result.Staged = append(result.Staged, path)
+		}
+
+		switch fileStatus.Worktree {
+		case git.Modified:
+			result.Modified = append(result.Modified, path)
+		case git.Deleted:
+			result.Deleted = append(result.Deleted, path)
+		case git.Untracked:
+			result.Untracked = append(result.Untracked, path)
+		}
+	}
+
+	result.Success = true
+	result.Clean = status.IsClean()
+	result.Message = "Status retrieved successfully"
+
+	logInfo("status succeeded", map[string]interface{}{"clean": result.Clean})
+	return result
+}
@@ -37,15 +37,16 @@ type RepositoryInfo struct {
 	ID     string `json:"id"`
 	Name   string `json:"name"`
 	Access string `json:"access"`
+	Host   string `json:"-"`
 }
 
 // CommitResult represents the result of a commit operation
 type CommitResult struct {
-	Success    bool   `json:"success"`
-	Message    string `json:"message"`
-	GitHash    string `json:"git_hash"`
-	MGitHash   string `json:"mgit_hash"`
-	CommitMsg  string `json:"commit_message"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+	GitHash   string `json:"git_hash"`
+	MGitHash  string `json:"mgit_hash"`
+	CommitMsg string `json:"commit_message"`
 }
 
 // PushResult represents the result of a push operation
@@ -79,4 +80,68 @@ type MCommitInfo struct {
 	Committer    MGitSignature `json:"committer"`
 	ParentHashes []string      `json:"parent_hashes"`
 	TreeHash     string        `json:"tree_hash"`
-}
\ No newline at end of file
+}
+
+// StatusResult represents the result of a status operation
+type StatusResult struct {
+	Success   bool     `json:"success"`
+	Message   string   `json:"message"`
+	Branch    string   `json:"branch"`
+	Clean     bool     `json:"clean"`
+	Staged    []string `json:"staged"`
+	Modified  []string `json:"modified"`
+	Untracked []string `json:"untracked"`
+	Deleted   []string `json:"deleted"`
+}
+
+// VerifyResult represents the result of an MGit commit chain verification
+type VerifyResult struct {
+	Success         bool   `json:"success"`
+	Message         string `json:"message"`
+	Valid           bool   `json:"valid"`
+	CommitsVerified int    `json:"commits_verified"`
+	FailedGitHash   string `json:"failed_git_hash,omitempty"`
+	FailureReason   string `json:"failure_reason,omitempty"`
+}
+
+// CloneOptions controls how CloneWithOptions clones a repository, letting a
+// mobile caller trade off completeness for bandwidth on cellular
+type CloneOptions struct {
+	URL           string
+	LocalPath     string
+	Token         string
+	Depth         int
+	SingleBranch  bool
+	Branch        string
+	NoSubmodules  bool
+	NoCheckout    bool
+	PrivateKeyPEM string
+	Passphrase    string
+
+	// HostKeyFingerprint pins the SSH server's host key for ssh:// / git@
+	// clones, in OpenSSH's "SHA256:<base64>" form (the same string `ssh
+	// -o FingerprintHash=sha256` or `ssh-keygen -lf` prints). Left empty,
+	// the connection falls back to accepting any host key, which is only
+	// appropriate when the transport itself is already trusted.
+	HostKeyFingerprint string
+}
+
+// LFSResult represents the result of a Git LFS fetch operation
+type LFSResult struct {
+	Success        bool   `json:"success"`
+	Message        string `json:"message"`
+	ObjectsFetched int    `json:"objects_fetched"`
+	BytesFetched   int64  `json:"bytes_fetched"`
+}
+
+// HashMapping represents a single entry in .mgit/mappings/hash_mappings.json,
+// linking a git commit to its MGit hash and nostr signature
+type HashMapping struct {
+	GitHash          string   `json:"git_hash"`
+	MGitHash         string   `json:"mgit_hash"`
+	ParentMGitHashes []string `json:"parent_mgit_hashes"`
+	AuthorPubkey     string   `json:"author_pubkey"`
+	Signature        string   `json:"signature,omitempty"`
+	Message          string   `json:"message"`
+	Timestamp        string   `json:"timestamp"`
+}
@@ -0,0 +1,139 @@
+package MGitBridge
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Verify is a thin wrapper around VerifyChain, kept for backward compatibility
+func Verify(repoPath string) *VerifyResult {
+	return VerifyChain(repoPath)
+}
+
+// VerifyChain walks the commit history from HEAD back to the root and, for
+// every commit, (1) recomputes its MGit hash from the git hash, parent MGit
+// hashes, and author pubkey, (2) verifies the nostr schnorr signature over
+// that hash, and (3) checks that the commit's declared parent MGit hashes
+// match its actual git parents', so the chain can't be reordered or forked
+func VerifyChain(repoPath string) *VerifyResult {
+	logInfo("verify chain started", map[string]interface{}{"repo_path": repoPath})
+
+	result := &VerifyResult{
+		Success: false,
+		Message: "",
+	}
+
+	if repoPath == "" {
+		result.Message = "Repository path cannot be empty"
+		return result
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		result.Message = fmt.Sprintf("error opening repository: %s", err.Error())
+		return result
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		result.Message = fmt.Sprintf("error reading HEAD: %s", err.Error())
+		return result
+	}
+
+	mappings, err := loadHashMappings(repoPath)
+	if err != nil {
+		result.Message = fmt.Sprintf("error loading hash_mappings.json: %s", err.Error())
+		return result
+	}
+
+	commitIter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		result.Message = fmt.Sprintf("error walking commit log: %s", err.Error())
+		return result
+	}
+	defer commitIter.Close()
+
+	verified := 0
+	failedGitHash := ""
+	walkErr := commitIter.ForEach(func(c *object.Commit) error {
+		mapping := findHashMapping(mappings, c.Hash.String())
+		if mapping == nil {
+			failedGitHash = c.Hash.String()
+			return fmt.Errorf("no MGit hash mapping found for commit %s", c.Hash.String())
+		}
+
+		mgitHashBytes := computeMGitHashBytes(mapping.GitHash, mapping.ParentMGitHashes, mapping.AuthorPubkey)
+		expectedHash := hex.EncodeToString(mgitHashBytes[:])
+		if expectedHash != mapping.MGitHash {
+			failedGitHash = c.Hash.String()
+			return fmt.Errorf("MGit hash mismatch for commit %s", c.Hash.String())
+		}
+
+		if mapping.Signature == "" {
+			failedGitHash = c.Hash.String()
+			return fmt.Errorf("commit %s has no nostr signature recorded", c.Hash.String())
+		}
+		if err := verifyNostrSignature(mgitHashBytes, mapping.AuthorPubkey, mapping.Signature); err != nil {
+			failedGitHash = c.Hash.String()
+			return fmt.Errorf("signature verification failed for commit %s: %w", c.Hash.String(), err)
+		}
+
+		var actualParentMGitHashes []string
+		for _, parentHash := range c.ParentHashes {
+			parentMapping := findHashMapping(mappings, parentHash.String())
+			if parentMapping == nil {
+				failedGitHash = c.Hash.String()
+				return fmt.Errorf("no MGit hash mapping found for parent commit %s", parentHash.String())
+			}
+			actualParentMGitHashes = append(actualParentMGitHashes, parentMapping.MGitHash)
+		}
+		if !sameStringSet(mapping.ParentMGitHashes, actualParentMGitHashes) {
+			failedGitHash = c.Hash.String()
+			return fmt.Errorf("commit %s declares parent MGit hashes that don't match its actual git parents", c.Hash.String())
+		}
+
+		verified++
+		return nil
+	})
+
+	result.CommitsVerified = verified
+	if walkErr != nil {
+		result.Success = true
+		result.Valid = false
+		result.Message = "MGit hash chain verification failed"
+		result.FailedGitHash = failedGitHash
+		result.FailureReason = walkErr.Error()
+		logWarn("verify chain found a broken chain", map[string]interface{}{"commits_verified": verified, "error": walkErr.Error()})
+		return result
+	}
+
+	result.Success = true
+	result.Valid = true
+	result.Message = "MGit hash chain verified successfully"
+
+	logInfo("verify chain succeeded", map[string]interface{}{"commits_verified": verified})
+	return result
+}
+
+// sameStringSet reports whether a and b contain exactly the same elements,
+// counting duplicates, regardless of order
+func sameStringSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		counts[s]--
+		if counts[s] < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,28 @@
+package MGitBridge
+
+import "testing"
+
+func TestSameStringSet(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want bool
+	}{
+		{"both empty", nil, nil, true},
+		{"same order", []string{"a", "b"}, []string{"a", "b"}, true},
+		{"different order", []string{"a", "b"}, []string{"b", "a"}, true},
+		{"different length", []string{"a"}, []string{"a", "b"}, false},
+		{"different elements", []string{"a", "b"}, []string{"a", "c"}, false},
+		{"duplicate counts must match", []string{"a", "a"}, []string{"a", "b"}, false},
+		{"duplicates in same multiset", []string{"a", "a", "b"}, []string{"a", "b", "a"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameStringSet(tt.a, tt.b); got != tt.want {
+				t.Errorf("sameStringSet(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}